@@ -0,0 +1,33 @@
+// Package httperr provides RFC 7807 ("Problem Details for HTTP APIs")
+// error responses shared across the api-go handlers.
+package httperr
+
+import (
+    "encoding/json"
+    "net/http"
+)
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+    Type     string `json:"type"`
+    Title    string `json:"title"`
+    Status   int    `json:"status"`
+    Detail   string `json:"detail,omitempty"`
+    Instance string `json:"instance,omitempty"`
+}
+
+// WriteProblem writes a Problem as application/problem+json for the given
+// status code. detail is a human-readable explanation specific to this
+// occurrence of the problem; Instance is filled in from the request path.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+    p := Problem{
+        Type:     "about:blank",
+        Title:    http.StatusText(status),
+        Status:   status,
+        Detail:   detail,
+        Instance: r.URL.Path,
+    }
+    w.Header().Set("Content-Type", "application/problem+json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,38 @@
+package httperr
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestWriteProblem(t *testing.T) {
+    req := httptest.NewRequest(http.MethodGet, "/widgets/42", nil)
+    rec := httptest.NewRecorder()
+
+    WriteProblem(rec, req, http.StatusNotFound, "widget 42 does not exist")
+
+    if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+        t.Errorf("Content-Type = %q, want application/problem+json", ct)
+    }
+    if rec.Code != http.StatusNotFound {
+        t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+    }
+
+    var got Problem
+    if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+        t.Fatalf("decode body: %v", err)
+    }
+
+    want := Problem{
+        Type:     "about:blank",
+        Title:    "Not Found",
+        Status:   http.StatusNotFound,
+        Detail:   "widget 42 does not exist",
+        Instance: "/widgets/42",
+    }
+    if got != want {
+        t.Errorf("got %+v, want %+v", got, want)
+    }
+}
@@ -0,0 +1,220 @@
+// Package jsonrpc implements a JSON-RPC 2.0 endpoint over HTTP with a
+// pluggable method registry, so new RPC methods can be registered without
+// touching the transport.
+package jsonrpc
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "net/http"
+    "sort"
+    "sync"
+
+    "github.com/thisrohangupta/codex/apps/api-go/httperr"
+)
+
+// defaultMaxBodyBytes is used when a Registry is constructed and mounted
+// without a caller overriding MaxBodyBytes.
+const defaultMaxBodyBytes = 128 * 1024
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+    CodeParseError     = -32700
+    CodeInvalidRequest = -32600
+    CodeMethodNotFound = -32601
+    CodeInvalidParams  = -32602
+    CodeInternalError  = -32603
+)
+
+// Handler implements one RPC method. Returning an *Error gives the caller
+// control over the code and message; any other error is reported as
+// CodeInternalError.
+type Handler func(ctx context.Context, params json.RawMessage) (any, error)
+
+// Error is a JSON-RPC 2.0 error object. It implements the error interface
+// so handlers can return one directly to control the response's code.
+type Error struct {
+    Code    int    `json:"code"`
+    Message string `json:"message"`
+    Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string { return e.Message }
+
+type request struct {
+    JSONRPC string           `json:"jsonrpc"`
+    Method  string           `json:"method"`
+    Params  json.RawMessage  `json:"params,omitempty"`
+    ID      *json.RawMessage `json:"id,omitempty"`
+}
+
+type response struct {
+    JSONRPC string
+    ID      json.RawMessage
+    Result  any
+    Error   *Error
+}
+
+// MarshalJSON emits exactly one of result/error, per JSON-RPC 2.0 §5: a
+// success response always carries "result" even when its value is the
+// zero value (0, false, "", nil, ...), so this can't use `omitempty` on
+// Result — that would drop legitimate zero-value results.
+func (r *response) MarshalJSON() ([]byte, error) {
+    if r.Error != nil {
+        return json.Marshal(struct {
+            JSONRPC string          `json:"jsonrpc"`
+            ID      json.RawMessage `json:"id"`
+            Error   *Error          `json:"error"`
+        }{r.JSONRPC, r.ID, r.Error})
+    }
+    return json.Marshal(struct {
+        JSONRPC string          `json:"jsonrpc"`
+        ID      json.RawMessage `json:"id"`
+        Result  any             `json:"result"`
+    }{r.JSONRPC, r.ID, r.Result})
+}
+
+// Registry maps method names to handlers and serves them over HTTP as
+// JSON-RPC 2.0, supporting both single requests and batches.
+type Registry struct {
+    // MaxBodyBytes caps request body size; requests over this limit are
+    // rejected with 413. Defaults to 128 KiB; a mounting Server overrides
+    // it with its own configured Server.MaxBodyBytes.
+    MaxBodyBytes int64
+
+    mu      sync.RWMutex
+    methods map[string]Handler
+}
+
+// NewRegistry returns a Registry with the built-in system.* methods
+// registered.
+func NewRegistry() *Registry {
+    reg := &Registry{methods: make(map[string]Handler), MaxBodyBytes: defaultMaxBodyBytes}
+    reg.Register("system.ping", systemPing)
+    reg.Register("system.listMethods", reg.systemListMethods)
+    return reg
+}
+
+// Register adds or replaces the handler for name.
+func (reg *Registry) Register(name string, h Handler) {
+    reg.mu.Lock()
+    defer reg.mu.Unlock()
+    reg.methods[name] = h
+}
+
+func (reg *Registry) lookup(name string) (Handler, bool) {
+    reg.mu.RLock()
+    defer reg.mu.RUnlock()
+    h, ok := reg.methods[name]
+    return h, ok
+}
+
+func systemPing(ctx context.Context, params json.RawMessage) (any, error) {
+    return "pong", nil
+}
+
+func (reg *Registry) systemListMethods(ctx context.Context, params json.RawMessage) (any, error) {
+    reg.mu.RLock()
+    defer reg.mu.RUnlock()
+    names := make([]string, 0, len(reg.methods))
+    for name := range reg.methods {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names, nil
+}
+
+// ServeHTTP implements http.Handler, accepting a single JSON-RPC request
+// object or a batch array.
+func (reg *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        httperr.WriteProblem(w, r, http.StatusMethodNotAllowed, "only POST is supported on this endpoint")
+        return
+    }
+
+    body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, reg.MaxBodyBytes))
+    if err != nil {
+        httperr.WriteProblem(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", reg.MaxBodyBytes))
+        return
+    }
+
+    w.Header().Set("Content-Type", "application/json")
+    trimmed := bytes.TrimSpace(body)
+
+    if len(trimmed) > 0 && trimmed[0] == '[' {
+        var batch []json.RawMessage
+        if err := json.Unmarshal(trimmed, &batch); err != nil {
+            json.NewEncoder(w).Encode(errorResponse(nil, CodeParseError, "invalid JSON"))
+            return
+        }
+        if len(batch) == 0 {
+            json.NewEncoder(w).Encode(errorResponse(nil, CodeInvalidRequest, "empty batch"))
+            return
+        }
+        responses := make([]*response, 0, len(batch))
+        for _, raw := range batch {
+            if resp := reg.handle(r.Context(), raw); resp != nil {
+                responses = append(responses, resp)
+            }
+        }
+        if len(responses) == 0 {
+            // All batch members were notifications: per JSON-RPC 2.0 §6 the
+            // server MUST NOT return an empty array, it must return nothing.
+            return
+        }
+        json.NewEncoder(w).Encode(responses)
+        return
+    }
+
+    if resp := reg.handle(r.Context(), trimmed); resp != nil {
+        json.NewEncoder(w).Encode(resp)
+    }
+}
+
+// handle processes a single JSON-RPC request and returns its response, or
+// nil if the request was a notification (no id) and needs no response.
+func (reg *Registry) handle(ctx context.Context, raw json.RawMessage) *response {
+    var req request
+    if err := json.Unmarshal(raw, &req); err != nil {
+        return errorResponse(nil, CodeParseError, "invalid JSON")
+    }
+    if req.JSONRPC != "2.0" || req.Method == "" {
+        if req.ID == nil {
+            return nil
+        }
+        return errorResponse(req.ID, CodeInvalidRequest, "jsonrpc must be \"2.0\" and method must be set")
+    }
+
+    h, ok := reg.lookup(req.Method)
+    if !ok {
+        if req.ID == nil {
+            return nil
+        }
+        return errorResponse(req.ID, CodeMethodNotFound, fmt.Sprintf("method not found: %s", req.Method))
+    }
+
+    result, err := h(ctx, req.Params)
+    if req.ID == nil {
+        return nil
+    }
+    if err != nil {
+        var rpcErr *Error
+        if errors.As(err, &rpcErr) {
+            return &response{JSONRPC: "2.0", ID: *req.ID, Error: rpcErr}
+        }
+        return errorResponse(req.ID, CodeInternalError, err.Error())
+    }
+    return &response{JSONRPC: "2.0", ID: *req.ID, Result: result}
+}
+
+func errorResponse(id *json.RawMessage, code int, message string) *response {
+    idVal := json.RawMessage("null")
+    if id != nil {
+        idVal = *id
+    }
+    return &response{JSONRPC: "2.0", ID: idVal, Error: &Error{Code: code, Message: message}}
+}
@@ -0,0 +1,77 @@
+package jsonrpc
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestRegistryHandleMethodNotFound(t *testing.T) {
+    reg := NewRegistry()
+
+    resp := reg.handle(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","method":"nope","id":1}`))
+
+    if resp == nil {
+        t.Fatal("handle returned nil, want an error response")
+    }
+    if resp.Error == nil || resp.Error.Code != CodeMethodNotFound {
+        t.Errorf("error = %+v, want code %d", resp.Error, CodeMethodNotFound)
+    }
+}
+
+func TestRegistryHandleParseError(t *testing.T) {
+    reg := NewRegistry()
+
+    resp := reg.handle(context.Background(), json.RawMessage(`not json`))
+
+    if resp == nil {
+        t.Fatal("handle returned nil, want an error response")
+    }
+    if resp.Error == nil || resp.Error.Code != CodeParseError {
+        t.Errorf("error = %+v, want code %d", resp.Error, CodeParseError)
+    }
+}
+
+func TestRegistryHandleNotificationIsSilent(t *testing.T) {
+    reg := NewRegistry()
+
+    // A notification (no id) to an unknown method must not produce a
+    // response, per JSON-RPC 2.0 §4.1.
+    resp := reg.handle(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","method":"nope"}`))
+
+    if resp != nil {
+        t.Errorf("handle = %+v, want nil for a notification", resp)
+    }
+}
+
+func TestRegistrySystemPing(t *testing.T) {
+    reg := NewRegistry()
+
+    resp := reg.handle(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","method":"system.ping","id":1}`))
+
+    if resp == nil || resp.Error != nil {
+        t.Fatalf("handle = %+v, want a successful result", resp)
+    }
+    if resp.Result != "pong" {
+        t.Errorf("result = %v, want %q", resp.Result, "pong")
+    }
+}
+
+func TestRegistryServeHTTPBatchOfNotificationsIsEmpty(t *testing.T) {
+    reg := NewRegistry()
+
+    batch := `[{"jsonrpc":"2.0","method":"system.ping"},{"jsonrpc":"2.0","method":"system.ping"}]`
+    req := httptest.NewRequest(http.MethodPost, "/rpc", strings.NewReader(batch))
+    rec := httptest.NewRecorder()
+
+    reg.ServeHTTP(rec, req)
+
+    // Per JSON-RPC 2.0 §6, a batch of only notifications must produce no
+    // response body at all, not an empty array.
+    if body := rec.Body.String(); body != "" {
+        t.Errorf("body = %q, want empty for a notification-only batch", body)
+    }
+}
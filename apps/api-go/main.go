@@ -1,19 +1,58 @@
 package main
 
 import (
-    "encoding/json"
+    "context"
     "log"
-    "net/http"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+
+    "github.com/thisrohangupta/codex/apps/api-go/observability"
+    "github.com/thisrohangupta/codex/apps/api-go/server"
 )
 
-func handler(w http.ResponseWriter, r *http.Request) {
-    w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(map[string]string{"service": "api-go", "status": "ok"})
+func main() {
+    addr := resolveAddr()
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    metrics := observability.NewMetrics()
+    opts := []server.Option{server.WithMetrics(metrics)}
+
+    if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+        tp, err := observability.NewTracerProvider(ctx, "api-go", endpoint)
+        if err != nil {
+            log.Printf("observability: failed to start tracer provider: %v", err)
+        } else {
+            defer func() {
+                shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+                defer cancel()
+                _ = tp.Shutdown(shutdownCtx)
+            }()
+            opts = append(opts, server.WithTracer(tp.Tracer("api-go")))
+        }
+    }
+
+    srv := server.New(addr, opts...)
+
+    log.Printf("api-go listening on %s", addr)
+    if err := srv.Run(ctx); err != nil {
+        log.Fatal(err)
+    }
 }
 
-func main() {
-    http.HandleFunc("/", handler)
-    log.Println("api-go listening on :8080")
-    log.Fatal(http.ListenAndServe(":8080", nil))
+// resolveAddr reads the bind address from ADDR if set, otherwise from PORT
+// (defaulting to 8080), matching the convention used by the relui example.
+func resolveAddr() string {
+    if addr := os.Getenv("ADDR"); addr != "" {
+        return addr
+    }
+    port := os.Getenv("PORT")
+    if port == "" {
+        port = "8080"
+    }
+    return ":" + port
 }
 
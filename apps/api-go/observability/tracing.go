@@ -0,0 +1,62 @@
+package observability
+
+import (
+    "context"
+    "net/http"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+    "go.opentelemetry.io/otel/propagation"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+    "go.opentelemetry.io/otel/trace"
+)
+
+// TraceMiddleware starts a span named route for every request, extracting
+// an incoming traceparent header so spans connect across service
+// boundaries, and records the resulting status code and error on the span.
+func TraceMiddleware(tracer trace.Tracer, route string, next http.Handler) http.Handler {
+    propagator := otel.GetTextMapPropagator()
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+        ctx, span := tracer.Start(ctx, route, trace.WithAttributes(
+            attribute.String("http.method", r.Method),
+            attribute.String("http.route", route),
+        ))
+        defer span.End()
+
+        rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+        next.ServeHTTP(rec, r.WithContext(ctx))
+
+        span.SetAttributes(attribute.Int("http.status_code", rec.status))
+        if rec.status >= http.StatusInternalServerError {
+            span.SetStatus(codes.Error, http.StatusText(rec.status))
+        }
+    })
+}
+
+// NewTracerProvider builds a TracerProvider that exports spans via OTLP/HTTP
+// to endpoint (typically OTEL_EXPORTER_OTLP_ENDPOINT), labeled as
+// serviceName. Callers must Shutdown the returned provider during graceful
+// shutdown so buffered spans are flushed.
+func NewTracerProvider(ctx context.Context, serviceName, endpoint string) (*sdktrace.TracerProvider, error) {
+    exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure())
+    if err != nil {
+        return nil, err
+    }
+
+    res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName(serviceName)))
+    if err != nil {
+        return nil, err
+    }
+
+    tp := sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+    )
+    otel.SetTracerProvider(tp)
+    return tp, nil
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+    "compress/gzip"
+    "context"
+    "crypto/rand"
+    "encoding/json"
+    "fmt"
+    "log"
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/thisrohangupta/codex/apps/api-go/httperr"
+)
+
+// middleware wraps a handler with additional behavior.
+type middleware func(http.Handler) http.Handler
+
+// chain applies middlewares to h in order, so the first middleware listed
+// runs outermost (first to see the request, last to see the response).
+func chain(h http.Handler, middlewares ...middleware) http.Handler {
+    for i := len(middlewares) - 1; i >= 0; i-- {
+        h = middlewares[i](h)
+    }
+    return h
+}
+
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+// requestID assigns each request a unique ID, propagating one supplied via
+// the X-Request-Id header and otherwise generating one, and echoes it back
+// on the response.
+func requestID(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        id := r.Header.Get("X-Request-Id")
+        if id == "" {
+            id = newRequestID()
+        }
+        w.Header().Set("X-Request-Id", id)
+        ctx := context.WithValue(r.Context(), requestIDKey, id)
+        next.ServeHTTP(w, r.WithContext(ctx))
+    })
+}
+
+func newRequestID() string {
+    var b [16]byte
+    if _, err := rand.Read(b[:]); err != nil {
+        return fmt.Sprintf("%d", time.Now().UnixNano())
+    }
+    return fmt.Sprintf("%x", b)
+}
+
+// statusRecorder captures the status code written so access logging and
+// metrics middleware can observe it after the handler returns.
+type statusRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+    r.status = status
+    r.ResponseWriter.WriteHeader(status)
+}
+
+// accessLog writes one structured JSON line per request to the standard
+// logger: method, path, status, request ID, and latency.
+func accessLog(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+        next.ServeHTTP(rec, r)
+
+        entry := struct {
+            Method    string `json:"method"`
+            Path      string `json:"path"`
+            Status    int    `json:"status"`
+            RequestID string `json:"request_id"`
+            DurationMs float64 `json:"duration_ms"`
+        }{
+            Method:     r.Method,
+            Path:       r.URL.Path,
+            Status:     rec.status,
+            RequestID:  fmt.Sprintf("%v", r.Context().Value(requestIDKey)),
+            DurationMs: float64(time.Since(start).Microseconds()) / 1000,
+        }
+        if b, err := json.Marshal(entry); err == nil {
+            log.Println(string(b))
+        }
+    })
+}
+
+// recoverPanic converts a panic in the handler chain into a 500 response
+// instead of crashing the server.
+func recoverPanic(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        defer func() {
+            if rec := recover(); rec != nil {
+                log.Printf("panic recovered: %v", rec)
+                httperr.WriteProblem(w, r, http.StatusInternalServerError, "internal server error")
+            }
+        }()
+        next.ServeHTTP(w, r)
+    })
+}
+
+// cors applies a permissive default CORS policy suitable for a public JSON
+// API with no cookie-based auth.
+func cors(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Access-Control-Allow-Origin", "*")
+        w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+        w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Request-Id")
+        if r.Method == http.MethodOptions {
+            w.WriteHeader(http.StatusNoContent)
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// validateRequest rejects requests whose Content-Length exceeds maxBodyBytes
+// with 413, requires Content-Type: application/json on POST/PUT/PATCH with
+// 415, and wraps the body in http.MaxBytesReader so streaming decodes that
+// exceed the cap fail safely too. Modeled on geth's validateRequest.
+func validateRequest(maxBodyBytes int64) middleware {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            if r.ContentLength > maxBodyBytes {
+                httperr.WriteProblem(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body exceeds %d bytes", maxBodyBytes))
+                return
+            }
+
+            switch r.Method {
+            case http.MethodPost, http.MethodPut, http.MethodPatch:
+                if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+                    httperr.WriteProblem(w, r, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+                    return
+                }
+            }
+
+            r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+            next.ServeHTTP(w, r)
+        })
+    }
+}
+
+type gzipResponseWriter struct {
+    http.ResponseWriter
+    writer *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+    return w.writer.Write(b)
+}
+
+// gzipCompress compresses the response body when the client advertises
+// support for it via Accept-Encoding.
+func gzipCompress(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+            next.ServeHTTP(w, r)
+            return
+        }
+        w.Header().Set("Content-Encoding", "gzip")
+        w.Header().Add("Vary", "Accept-Encoding")
+        gz := gzip.NewWriter(w)
+        defer gz.Close()
+        next.ServeHTTP(&gzipResponseWriter{ResponseWriter: w, writer: gz}, r)
+    })
+}
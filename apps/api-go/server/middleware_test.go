@@ -0,0 +1,77 @@
+package server
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestValidateRequest(t *testing.T) {
+    ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.WriteHeader(http.StatusOK)
+    })
+
+    tests := []struct {
+        name       string
+        method     string
+        contentLen int64
+        contentTyp string
+        wantStatus int
+    }{
+        {
+            name:       "body over cap is rejected",
+            method:     http.MethodPost,
+            contentLen: 200,
+            contentTyp: "application/json",
+            wantStatus: http.StatusRequestEntityTooLarge,
+        },
+        {
+            name:       "missing content-type on POST is rejected",
+            method:     http.MethodPost,
+            contentLen: 2,
+            contentTyp: "",
+            wantStatus: http.StatusUnsupportedMediaType,
+        },
+        {
+            name:       "non-json content-type on PUT is rejected",
+            method:     http.MethodPut,
+            contentLen: 2,
+            contentTyp: "text/plain",
+            wantStatus: http.StatusUnsupportedMediaType,
+        },
+        {
+            name:       "json POST within cap is allowed",
+            method:     http.MethodPost,
+            contentLen: 2,
+            contentTyp: "application/json",
+            wantStatus: http.StatusOK,
+        },
+        {
+            name:       "GET is exempt from content-type checks",
+            method:     http.MethodGet,
+            contentLen: 0,
+            contentTyp: "",
+            wantStatus: http.StatusOK,
+        },
+    }
+
+    const maxBodyBytes = 100
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            req := httptest.NewRequest(tt.method, "/", strings.NewReader("{}"))
+            req.ContentLength = tt.contentLen
+            if tt.contentTyp != "" {
+                req.Header.Set("Content-Type", tt.contentTyp)
+            }
+            rec := httptest.NewRecorder()
+
+            validateRequest(maxBodyBytes)(ok).ServeHTTP(rec, req)
+
+            if rec.Code != tt.wantStatus {
+                t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+            }
+        })
+    }
+}
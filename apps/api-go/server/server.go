@@ -0,0 +1,164 @@
+// Package server assembles the api-go HTTP server: route registration, the
+// middleware chain every route runs through, and graceful shutdown.
+package server
+
+import (
+    "context"
+    "encoding/json"
+    "net/http"
+    "time"
+
+    "go.opentelemetry.io/otel/trace"
+    "go.opentelemetry.io/otel/trace/noop"
+
+    "github.com/thisrohangupta/codex/apps/api-go/httperr"
+    "github.com/thisrohangupta/codex/apps/api-go/jsonrpc"
+    "github.com/thisrohangupta/codex/apps/api-go/observability"
+)
+
+// defaultShutdownTimeout bounds how long Run waits for in-flight requests to
+// finish once its context is canceled.
+const defaultShutdownTimeout = 10 * time.Second
+
+// defaultMaxBodyBytes caps request bodies at 128 KiB unless overridden.
+const defaultMaxBodyBytes = 128 * 1024
+
+// Server owns the routes and middleware chain for api-go.
+type Server struct {
+    // Addr is the address passed to http.Server, e.g. ":8080".
+    Addr string
+    // ShutdownTimeout bounds graceful shutdown. Defaults to 10s.
+    ShutdownTimeout time.Duration
+    // MaxBodyBytes caps request body size; requests over this limit are
+    // rejected with 413. Defaults to 128 KiB.
+    MaxBodyBytes int64
+
+    mux     *http.ServeMux
+    rpc     *jsonrpc.Registry
+    metrics *observability.Metrics
+    tracer  trace.Tracer
+}
+
+// Option configures optional Server dependencies, letting callers (and
+// tests) inject their own metrics registry or tracer instead of the
+// defaults New constructs.
+type Option func(*Server)
+
+// WithMetrics overrides the Metrics used to instrument every route.
+func WithMetrics(m *observability.Metrics) Option {
+    return func(s *Server) { s.metrics = m }
+}
+
+// WithTracer overrides the tracer used to start a span per request.
+func WithTracer(t trace.Tracer) Option {
+    return func(s *Server) { s.tracer = t }
+}
+
+// New builds a Server with all routes registered and ready to Run.
+func New(addr string, opts ...Option) *Server {
+    s := &Server{
+        Addr:            addr,
+        ShutdownTimeout: defaultShutdownTimeout,
+        MaxBodyBytes:    defaultMaxBodyBytes,
+        mux:             http.NewServeMux(),
+        rpc:             jsonrpc.NewRegistry(),
+        metrics:         observability.NewMetrics(),
+        tracer:          noop.NewTracerProvider().Tracer("api-go"),
+    }
+    for _, opt := range opts {
+        opt(s)
+    }
+    // Thread the configured cap into the registry so /rpc honors the same
+    // tunable knob as every other route instead of its own fixed default.
+    s.rpc.MaxBodyBytes = s.MaxBodyBytes
+    s.routes()
+    return s
+}
+
+// RPC returns the JSON-RPC method registry so callers can register
+// additional methods beyond the built-in system.* ones.
+func (s *Server) RPC() *jsonrpc.Registry {
+    return s.rpc
+}
+
+func (s *Server) routes() {
+    s.handle("/healthz", http.HandlerFunc(s.handleHealthz))
+    s.handle("/readyz", http.HandlerFunc(s.handleReadyz))
+    s.handle("/rpc", s.rpc)
+    s.handle("/", http.HandlerFunc(s.handleRoot)) // kept for backwards compatibility
+    s.mux.Handle("/metrics", s.metrics.Handler())
+}
+
+// handle registers h at pattern wrapped with the metrics and tracing
+// middleware, so every route gets golden-signal metrics and a span without
+// any per-handler instrumentation code.
+func (s *Server) handle(pattern string, h http.Handler) {
+    wrapped := observability.TraceMiddleware(s.tracer, pattern, h)
+    wrapped = s.metrics.Middleware(pattern, wrapped)
+    s.mux.Handle(pattern, wrapped)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodGet {
+        httperr.WriteProblem(w, r, http.StatusMethodNotAllowed, "only GET is supported on this endpoint")
+        return
+    }
+    w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodGet {
+        httperr.WriteProblem(w, r, http.StatusMethodNotAllowed, "only GET is supported on this endpoint")
+        return
+    }
+    w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+    // "/" is a catch-all in http.ServeMux: anything not matched by a more
+    // specific pattern lands here too, so unknown routes must be rejected
+    // explicitly instead of serving the root payload for every path.
+    if r.URL.Path != "/" {
+        httperr.WriteProblem(w, r, http.StatusNotFound, "no such resource")
+        return
+    }
+    if r.Method != http.MethodGet {
+        httperr.WriteProblem(w, r, http.StatusMethodNotAllowed, "only GET is supported on this endpoint")
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(map[string]string{"service": "api-go", "status": "ok"})
+}
+
+// Handler returns the fully wrapped handler: routes plus the middleware
+// chain (request ID, access log, panic recovery, CORS, body limits, gzip).
+func (s *Server) Handler() http.Handler {
+    return chain(s.mux, recoverPanic, requestID, accessLog, cors, validateRequest(s.MaxBodyBytes), gzipCompress)
+}
+
+// Run starts the HTTP server and blocks until ctx is canceled, at which
+// point it gracefully shuts down within ShutdownTimeout.
+func (s *Server) Run(ctx context.Context) error {
+    httpServer := &http.Server{
+        Addr:    s.Addr,
+        Handler: s.Handler(),
+    }
+
+    errCh := make(chan error, 1)
+    go func() {
+        if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            errCh <- err
+            return
+        }
+        errCh <- nil
+    }()
+
+    select {
+    case <-ctx.Done():
+        shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+        defer cancel()
+        return httpServer.Shutdown(shutdownCtx)
+    case err := <-errCh:
+        return err
+    }
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/thisrohangupta/codex/apps/api-go/httperr"
+)
+
+func TestHealthzRejectsNonGET(t *testing.T) {
+    srv := New(":0")
+
+    req := httptest.NewRequest(http.MethodPost, "/healthz", nil)
+    req.Header.Set("Content-Type", "application/json")
+    rec := httptest.NewRecorder()
+
+    srv.Handler().ServeHTTP(rec, req)
+
+    if rec.Code != http.StatusMethodNotAllowed {
+        t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+    }
+    if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+        t.Errorf("Content-Type = %q, want application/problem+json", ct)
+    }
+
+    var p httperr.Problem
+    if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+        t.Fatalf("decode body: %v", err)
+    }
+    if p.Status != http.StatusMethodNotAllowed {
+        t.Errorf("problem.Status = %d, want %d", p.Status, http.StatusMethodNotAllowed)
+    }
+}